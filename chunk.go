@@ -0,0 +1,258 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// The world is carved into 16x16x16 chunks purely for meshing/caching
+// purposes; the voxel grid itself (see world in world.go) doesn't know
+// about chunks at all.
+const chunkSize = 16
+
+// worldHeight is the vertical extent meshed into chunks, and also the hard
+// ceiling generateColumn clamps column heights to: base terrain tops out
+// around 32 and BiomeMountains.HeightMultiplier() scales that up to ~51, so
+// this needs to cover ~51 rounded up to a multiple of chunkSize.
+const worldHeight = 64
+
+// faceDir describes one of the 6 axis-aligned face directions a greedy
+// mesher sweeps: axis is the axis the face is perpendicular to (0=X, 1=Y,
+// 2=Z), sign is which side of a voxel along that axis the face sits on.
+type faceDir struct {
+	axis int
+	sign int
+}
+
+var faceDirs = [6]faceDir{
+	{axis: 0, sign: 1}, {axis: 0, sign: -1},
+	{axis: 1, sign: 1}, {axis: 1, sign: -1},
+	{axis: 2, sign: 1}, {axis: 2, sign: -1},
+}
+
+// chunkKey identifies one 16x16x16 chunk by its chunk-space coordinates
+// (world block coordinate / chunkSize).
+type chunkKey struct {
+	cx, cy, cz int
+}
+
+// cachedChunk holds the greedy-meshed rl.Model for one chunk. empty is true
+// when the chunk has no exposed faces at all (e.g. fully below ground or
+// fully air), so callers can skip drawing it without re-walking the chunk.
+type cachedChunk struct {
+	model rl.Model
+	empty bool
+}
+
+// chunkMeshCache builds and caches one merged rl.Model per chunk, so the
+// render loop draws a handful of models instead of one rl.DrawCube per
+// visible block. Call invalidate when a chunk's blocks change (see the
+// raycast place/break code) to force a rebuild on next access.
+type chunkMeshCache struct {
+	chunks map[chunkKey]*cachedChunk
+}
+
+func newChunkMeshCache() *chunkMeshCache {
+	return &chunkMeshCache{chunks: make(map[chunkKey]*cachedChunk)}
+}
+
+// modelFor returns the cached model for chunk (cx, cy, cz), building and
+// uploading it first if needed. ok is false if the chunk has no geometry to
+// draw.
+func (c *chunkMeshCache) modelFor(cx, cy, cz int, w *world, maxH int) (rl.Model, bool) {
+	key := chunkKey{cx, cy, cz}
+	if cached, found := c.chunks[key]; found {
+		return cached.model, !cached.empty
+	}
+
+	mesh, empty := buildChunkMesh(w, maxH, cx, cy, cz)
+	cached := &cachedChunk{empty: empty}
+	if !empty {
+		rl.UploadMesh(&mesh, false)
+		cached.model = rl.LoadModelFromMesh(mesh)
+	}
+	c.chunks[key] = cached
+	return cached.model, !cached.empty
+}
+
+// invalidate drops the cached model for chunk (cx, cy, cz), unloading its
+// GPU resources first. The next modelFor call rebuilds it from scratch.
+func (c *chunkMeshCache) invalidate(cx, cy, cz int) {
+	key := chunkKey{cx, cy, cz}
+	if cached, found := c.chunks[key]; found {
+		if !cached.empty {
+			rl.UnloadModel(cached.model)
+		}
+		delete(c.chunks, key)
+	}
+}
+
+// invalidateBlock invalidates whichever chunk contains world block (x, y, z),
+// plus any neighboring chunk whose mesh could have a face hidden or
+// revealed by this block (i.e. (x, y, z) sits on a chunk boundary).
+func (c *chunkMeshCache) invalidateBlock(x, y, z int) {
+	cx, cy, cz := floorDiv(x, chunkSize), floorDiv(y, chunkSize), floorDiv(z, chunkSize)
+	c.invalidate(cx, cy, cz)
+
+	lx, ly, lz := x-cx*chunkSize, y-cy*chunkSize, z-cz*chunkSize
+	if lx == 0 {
+		c.invalidate(cx-1, cy, cz)
+	} else if lx == chunkSize-1 {
+		c.invalidate(cx+1, cy, cz)
+	}
+	if ly == 0 {
+		c.invalidate(cx, cy-1, cz)
+	} else if ly == chunkSize-1 {
+		c.invalidate(cx, cy+1, cz)
+	}
+	if lz == 0 {
+		c.invalidate(cx, cy, cz-1)
+	} else if lz == chunkSize-1 {
+		c.invalidate(cx, cy, cz+1)
+	}
+}
+
+func floorDiv(a, b int) int {
+	if a >= 0 {
+		return a / b
+	}
+	return -((-a + b - 1) / b)
+}
+
+// buildChunkMesh greedy-meshes chunk (cx, cy, cz): for each of the 6 face
+// directions it sweeps slice by slice, builds a 2D mask of exposed faces,
+// and greedily merges runs of equal-colored faces into single quads
+// (Mikola Lysenko's algorithm). It returns a single rl.Mesh covering every
+// merged quad in the chunk, or empty=true if the chunk has no exposed
+// faces at all.
+func buildChunkMesh(w *world, maxH, cx, cy, cz int) (mesh rl.Mesh, empty bool) {
+	ox, oy, oz := cx*chunkSize, cy*chunkSize, cz*chunkSize
+
+	var vertices, normals []float32
+	var colors []uint8
+	var indices []uint16
+
+	addQuad := func(origin [3]int, du, dv [3]int, normal [3]float32, col rl.Color) {
+		p0 := [3]float32{float32(origin[0]), float32(origin[1]), float32(origin[2])}
+		p1 := [3]float32{p0[0] + float32(du[0]), p0[1] + float32(du[1]), p0[2] + float32(du[2])}
+		p2 := [3]float32{p1[0] + float32(dv[0]), p1[1] + float32(dv[1]), p1[2] + float32(dv[2])}
+		p3 := [3]float32{p0[0] + float32(dv[0]), p0[1] + float32(dv[1]), p0[2] + float32(dv[2])}
+
+		corners := [4][3]float32{p0, p1, p2, p3}
+		if normal[0]+normal[1]+normal[2] < 0 {
+			// Reverse winding so the face still points along normal; du/dv
+			// are unsigned (axis-local) so this is the only sign-dependent step.
+			corners = [4][3]float32{p0, p3, p2, p1}
+		}
+
+		base := uint16(len(vertices) / 3)
+		for _, p := range corners {
+			vertices = append(vertices, p[0], p[1], p[2])
+			normals = append(normals, normal[0], normal[1], normal[2])
+			colors = append(colors, col.R, col.G, col.B, col.A)
+		}
+		indices = append(indices, base, base+1, base+2, base, base+2, base+3)
+	}
+
+	for _, dir := range faceDirs {
+		axis := dir.axis
+		u := (axis + 1) % 3
+		v := (axis + 2) % 3
+
+		for layer := 0; layer < chunkSize; layer++ {
+			var mask [chunkSize][chunkSize]rl.Color
+			var present [chunkSize][chunkSize]bool
+
+			for a := 0; a < chunkSize; a++ {
+				for b := 0; b < chunkSize; b++ {
+					coord := [3]int{}
+					coord[axis] = layer
+					coord[u] = a
+					coord[v] = b
+					wx, wy, wz := ox+coord[0], oy+coord[1], oz+coord[2]
+					block := w.At(wx, wy, wz)
+					if block == BlockAir {
+						continue
+					}
+
+					nCoord := coord
+					nCoord[axis] += dir.sign
+					nwx, nwy, nwz := ox+nCoord[0], oy+nCoord[1], oz+nCoord[2]
+					if w.At(nwx, nwy, nwz) != BlockAir {
+						continue // face hidden by a neighboring solid block
+					}
+
+					mask[a][b] = blockColor(block, wy, maxH)
+					present[a][b] = true
+				}
+			}
+
+			var visited [chunkSize][chunkSize]bool
+			for a := 0; a < chunkSize; a++ {
+				for b := 0; b < chunkSize; b++ {
+					if visited[a][b] || !present[a][b] {
+						continue
+					}
+					col := mask[a][b]
+
+					rw := 1
+					for b+rw < chunkSize && !visited[a][b+rw] && present[a][b+rw] && mask[a][b+rw] == col {
+						rw++
+					}
+
+					rh := 1
+				expandHeight:
+					for a+rh < chunkSize {
+						for bb := b; bb < b+rw; bb++ {
+							if visited[a+rh][bb] || !present[a+rh][bb] || mask[a+rh][bb] != col {
+								break expandHeight
+							}
+						}
+						rh++
+					}
+
+					for aa := a; aa < a+rh; aa++ {
+						for bb := b; bb < b+rw; bb++ {
+							visited[aa][bb] = true
+						}
+					}
+
+					origin := [3]int{}
+					origin[axis] = layer
+					if dir.sign > 0 {
+						origin[axis]++ // face sits on the far boundary of the voxel
+					}
+					origin[u] = a
+					origin[v] = b
+					origin[0] += ox
+					origin[1] += oy
+					origin[2] += oz
+
+					du := [3]int{}
+					du[u] = rh
+					dv := [3]int{}
+					dv[v] = rw
+
+					normal := [3]float32{}
+					normal[axis] = float32(dir.sign)
+
+					addQuad(origin, du, dv, normal, col)
+				}
+			}
+		}
+	}
+
+	if len(indices) == 0 {
+		return rl.Mesh{}, true
+	}
+
+	mesh = rl.Mesh{
+		VertexCount:   int32(len(vertices) / 3),
+		TriangleCount: int32(len(indices) / 3),
+		Vertices:      vertices,
+		Normals:       normals,
+		Texcoords:     make([]float32, (len(vertices)/3)*2), // unused: faces are flat-shaded via vertex color
+		Colors:        colors,
+		Indices:       indices,
+	}
+	return mesh, false
+}
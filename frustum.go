@@ -0,0 +1,124 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// plane is a half-space boundary: points P with normal.P + d >= 0 are on the
+// "inside" (visible) side.
+type plane struct {
+	normal rl.Vector3
+	d      float32
+}
+
+// safeNormalize returns v normalized, or the zero vector if v is too short
+// to normalize without blowing up (e.g. degenerate corner rays at fov ~ 0).
+func safeNormalize(v rl.Vector3) rl.Vector3 {
+	lenSq := v.X*v.X + v.Y*v.Y + v.Z*v.Z
+	if lenSq < 1e-12 {
+		return rl.NewVector3(0, 0, 0)
+	}
+	return rl.Vector3Normalize(v)
+}
+
+// setPoints builds a plane through v1, v2, v3 (wound so the normal points
+// toward the inside of the frustum), following the standard construction:
+// N = (v3-v2) x (v1-v2), D = -N.v2.
+func setPoints(v1, v2, v3 rl.Vector3) plane {
+	aux1 := rl.Vector3Subtract(v1, v2)
+	aux2 := rl.Vector3Subtract(v3, v2)
+	normal := safeNormalize(rl.Vector3CrossProduct(aux2, aux1))
+	return plane{
+		normal: normal,
+		d:      -rl.Vector3DotProduct(normal, v2),
+	}
+}
+
+func (p plane) distance(point rl.Vector3) float32 {
+	return rl.Vector3DotProduct(p.normal, point) + p.d
+}
+
+// Plane indices into frustum.planes.
+const (
+	planeTop = iota
+	planeBottom
+	planeLeft
+	planeRight
+	planeNear
+	planeFar
+	planeCount
+)
+
+type frustum struct {
+	planes [planeCount]plane
+}
+
+// buildFrustum constructs the six view-frustum planes for the given camera
+// basis (forward/up/right must already be orthonormal), vertical FOV in
+// degrees, aspect ratio (width/height) and near/far clip distances.
+func buildFrustum(camPos, forward, up, right rl.Vector3, fovyDeg, aspect, near, far float32) frustum {
+	halfFovy := float64(fovyDeg) * math.Pi / 360.0
+	nh := near * float32(math.Tan(halfFovy))
+	nw := nh * aspect
+	fh := far * float32(math.Tan(halfFovy))
+	fw := fh * aspect
+
+	nc := rl.Vector3Add(camPos, rl.Vector3Scale(forward, near))
+	fc := rl.Vector3Add(camPos, rl.Vector3Scale(forward, far))
+
+	ntl := rl.Vector3Add(rl.Vector3Add(nc, rl.Vector3Scale(up, nh)), rl.Vector3Scale(right, -nw))
+	ntr := rl.Vector3Add(rl.Vector3Add(nc, rl.Vector3Scale(up, nh)), rl.Vector3Scale(right, nw))
+	nbl := rl.Vector3Add(rl.Vector3Add(nc, rl.Vector3Scale(up, -nh)), rl.Vector3Scale(right, -nw))
+	nbr := rl.Vector3Add(rl.Vector3Add(nc, rl.Vector3Scale(up, -nh)), rl.Vector3Scale(right, nw))
+
+	ftl := rl.Vector3Add(rl.Vector3Add(fc, rl.Vector3Scale(up, fh)), rl.Vector3Scale(right, -fw))
+	ftr := rl.Vector3Add(rl.Vector3Add(fc, rl.Vector3Scale(up, fh)), rl.Vector3Scale(right, fw))
+	fbl := rl.Vector3Add(rl.Vector3Add(fc, rl.Vector3Scale(up, -fh)), rl.Vector3Scale(right, -fw))
+	fbr := rl.Vector3Add(rl.Vector3Add(fc, rl.Vector3Scale(up, -fh)), rl.Vector3Scale(right, fw))
+
+	var f frustum
+	f.planes[planeTop] = setPoints(ntr, ntl, ftl)
+	f.planes[planeBottom] = setPoints(nbl, nbr, fbr)
+	f.planes[planeLeft] = setPoints(ntl, nbl, fbl)
+	f.planes[planeRight] = setPoints(nbr, ntr, fbr)
+	f.planes[planeNear] = setPoints(ntl, ntr, nbr)
+	f.planes[planeFar] = setPoints(ftr, ftl, fbl)
+	return f
+}
+
+// aabb is an axis-aligned box given by its min and max corners.
+type aabb struct {
+	min rl.Vector3
+	max rl.Vector3
+}
+
+// positiveVertex returns the box corner furthest along normal, i.e. the one
+// most likely to still be inside the half-space it bounds.
+func (b aabb) positiveVertex(normal rl.Vector3) rl.Vector3 {
+	p := b.min
+	if normal.X >= 0 {
+		p.X = b.max.X
+	}
+	if normal.Y >= 0 {
+		p.Y = b.max.Y
+	}
+	if normal.Z >= 0 {
+		p.Z = b.max.Z
+	}
+	return p
+}
+
+// intersectsAABB reports whether box could be at least partially visible.
+// It can report true for a box that lies just outside the frustum (near an
+// edge or corner), but never reports false for a box that is at least
+// partially inside, so it's safe to use as a cull test.
+func (f frustum) intersectsAABB(box aabb) bool {
+	for _, p := range f.planes {
+		if p.distance(box.positiveVertex(p.normal)) < 0 {
+			return false
+		}
+	}
+	return true
+}
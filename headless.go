@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/basperheim/minecraft-clone-raylib-go/render"
+)
+
+// inputFrame is one line of a -renderinput script: the look delta to apply
+// for that frame. dt is carried along for parity with the live loop but
+// isn't needed for a single still render.
+type inputFrame struct {
+	dt, dx, dy float32
+}
+
+// loadInputScript reads a whitespace-separated "dt dx dy" per line script.
+// Blank lines and lines starting with '#' are ignored.
+func loadInputScript(path string) ([]inputFrame, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var frames []inputFrame
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("headless: bad input line %q: want \"dt dx dy\"", line)
+		}
+		dt, err1 := strconv.ParseFloat(fields[0], 32)
+		dx, err2 := strconv.ParseFloat(fields[1], 32)
+		dy, err3 := strconv.ParseFloat(fields[2], 32)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("headless: bad input line %q", line)
+		}
+		frames = append(frames, inputFrame{float32(dt), float32(dx), float32(dy)})
+	}
+	return frames, scanner.Err()
+}
+
+// project maps a world-space point into framebuffer pixel coordinates plus a
+// view-space depth (larger = farther), using the same yaw/pitch camera
+// basis as the live renderer. ok is false if the point is behind the camera.
+func project(p, camPos, forward, up, right render.Vec3, fovyDeg float32, width, height int) (screen render.Vec3, ok bool) {
+	rel := p.Sub(camPos)
+	camX := rel.Dot(right)
+	camY := rel.Dot(up)
+	camZ := -rel.Dot(forward) // view space looks down -Z
+
+	const nearClip = 0.05
+	if camZ >= -nearClip {
+		return render.Vec3{}, false
+	}
+	depth := -camZ
+
+	fovyRad := float64(fovyDeg) * math.Pi / 180
+	tanHalf := float32(math.Tan(fovyRad / 2))
+	aspect := float32(width) / float32(height)
+
+	ndcX := camX / (depth * tanHalf * aspect)
+	ndcY := camY / (depth * tanHalf)
+
+	screen = render.Vec3{
+		X: (ndcX*0.5 + 0.5) * float32(width),
+		Y: (1 - (ndcY*0.5 + 0.5)) * float32(height),
+		Z: depth,
+	}
+	return screen, true
+}
+
+// toRenderColor converts one of shadeColumn's raylib colors into the
+// render package's own, dependency-free Color type.
+func toRenderColor(c rl.Color) render.Color {
+	return render.Color{R: c.R, G: c.G, B: c.B, A: c.A}
+}
+
+// toRenderVec3 converts a raylib Vector3 into the render package's own,
+// dependency-free Vec3 type, so the live camera basis can feed rasterizeWorld.
+func toRenderVec3(v rl.Vector3) render.Vec3 {
+	return render.Vec3{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// rasterizeWorld draws only the top face of every column within range of
+// camPos into fb using the software rasterizer, via a fixed world-up camera
+// basis (unlike the live frustum's cross(right, forward) up, this up does
+// not tilt with pitch). Both are deliberate simplifications, not oversights: this
+// is a stand-in for the live DrawModel loop close enough for a headless
+// screenshot / regression diff, not a full voxel renderer.
+func rasterizeWorld(fb *render.Framebuffer, w *world, worldSize int, camPos, forward render.Vec3) {
+	up := render.Vec3{X: 0, Y: 1, Z: 0}
+	right := render.Vec3{X: -forward.Z, Y: 0, Z: forward.X}.Normalize()
+	const fovyDeg = 60.0
+	const renderRadius = 48
+
+	px := int(camPos.X)
+	pz := int(camPos.Z)
+
+	for z := clampInt(pz-renderRadius, 0, worldSize-1); z <= clampInt(pz+renderRadius, 0, worldSize-1); z++ {
+		for x := clampInt(px-renderRadius, 0, worldSize-1); x <= clampInt(px+renderRadius, 0, worldSize-1); x++ {
+			h := w.GroundHeight(x, z)
+			if h <= 0 {
+				continue
+			}
+			y := float32(h - 1)
+			col := toRenderColor(blockColor(w.At(x, h-1, z), h-1, w.maxH))
+
+			corners := [4]render.Vec3{
+				{X: float32(x), Y: y + 1, Z: float32(z)},
+				{X: float32(x) + 1, Y: y + 1, Z: float32(z)},
+				{X: float32(x) + 1, Y: y + 1, Z: float32(z) + 1},
+				{X: float32(x), Y: y + 1, Z: float32(z) + 1},
+			}
+
+			var screen [4]render.Vec3
+			visible := true
+			for i, c := range corners {
+				s, ok := project(c, camPos, forward, up, right, fovyDeg, fb.Width, fb.Height)
+				if !ok {
+					visible = false
+					break
+				}
+				screen[i] = s
+			}
+			if !visible {
+				continue
+			}
+
+			fb.DrawTriangle(
+				render.Vertex{Pos: screen[0], Color: col},
+				render.Vertex{Pos: screen[1], Color: col},
+				render.Vertex{Pos: screen[2], Color: col},
+			)
+			fb.DrawTriangle(
+				render.Vertex{Pos: screen[0], Color: col},
+				render.Vertex{Pos: screen[2], Color: col},
+				render.Vertex{Pos: screen[3], Color: col},
+			)
+		}
+	}
+}
+
+// drawHighlight rasterizes mesh (expected to be a unit cube centered on the
+// origin, see assets/cube.obj) scaled up slightly and translated onto the
+// targeted block, as the CPU-rasterizer counterpart of the GPU path's
+// rl.DrawCubeWires outline.
+func drawHighlight(fb *render.Framebuffer, mesh *render.Mesh, targeted rayHit, camPos, forward, up, right render.Vec3) {
+	center := render.Vec3{X: float32(targeted.x) + 0.5, Y: float32(targeted.y) + 0.5, Z: float32(targeted.z) + 0.5}
+	const scale = 1.02
+	const fovyDeg = 60.0
+	col := render.Color{R: 0, G: 0, B: 0, A: 255}
+
+	for _, tri := range mesh.Triangles {
+		var screen [3]render.Vec3
+		visible := true
+		for i, v := range tri {
+			p := center.Add(v.Scale(scale))
+			s, ok := project(p, camPos, forward, up, right, fovyDeg, fb.Width, fb.Height)
+			if !ok {
+				visible = false
+				break
+			}
+			screen[i] = s
+		}
+		if !visible {
+			continue
+		}
+		fb.DrawTriangle(
+			render.Vertex{Pos: screen[0], Color: col},
+			render.Vertex{Pos: screen[1], Color: col},
+			render.Vertex{Pos: screen[2], Color: col},
+		)
+	}
+}
+
+// runHeadless replays a recorded look script against the voxel world and
+// rasterizes the resulting view with the CPU-only render package, writing
+// it to outPath. It never touches raylib's window or GPU path, so it can
+// run in CPU-only environments such as CI.
+func runHeadless(outPath, inPath string, seed uint64) {
+	const worldSize = 64
+	const width, height = 640, 360
+
+	w := generateWorld(worldSize, seed)
+
+	// yaw=0 (not math.Pi, the live game's spawn yaw) so the default still
+	// frame with no -renderinput actually faces the terrain: camPos sits at
+	// z=80, outside the world's z in [0, worldSize), so forward needs a -Z
+	// component to look back into it rather than away from it.
+	var yaw float32 = 0
+	var pitch float32 = -0.15
+	const mouseSens float32 = 0.003
+	const limit = float32(math.Pi / 3)
+
+	if inPath != "" {
+		frames, err := loadInputScript(inPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "headless: %v\n", err)
+			os.Exit(1)
+		}
+		for _, in := range frames {
+			yaw -= in.dx * mouseSens
+			pitch -= in.dy * mouseSens
+			if pitch > limit {
+				pitch = limit
+			}
+			if pitch < -limit {
+				pitch = -limit
+			}
+		}
+	}
+
+	cp := float32(math.Cos(float64(pitch)))
+	sp := float32(math.Sin(float64(pitch)))
+	sy := float32(math.Sin(float64(yaw)))
+	cy := float32(math.Cos(float64(yaw)))
+	forward := render.Vec3{X: cp * sy, Y: sp, Z: -cp * cy}.Normalize()
+	camPos := render.Vec3{X: 32, Y: 28, Z: 80}
+
+	fb := render.NewFramebuffer(width, height)
+	fb.Clear(render.Color{R: 135, G: 206, B: 235, A: 255})
+
+	rasterizeWorld(fb, w, worldSize, camPos, forward)
+
+	if err := fb.SavePNG(outPath); err != nil {
+		fmt.Fprintf(os.Stderr, "headless: writing %s: %v\n", outPath, err)
+		os.Exit(1)
+	}
+}
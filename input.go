@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Key bits packed into one recordedFrame's keybits, in no particular order;
+// each just needs to round-trip through a recording.
+const (
+	keyW uint16 = 1 << iota
+	keyS
+	keyA
+	keyD
+	keyShift
+	keySpace
+	keyTab
+	keyP
+	mouseLeft
+	mouseRight
+)
+
+// recordedFrame is everything the game loop reads from input in one frame,
+// snapshotted so a replay log is one fixed-size binary record per frame.
+type recordedFrame struct {
+	dt      float32
+	mouseDX float32
+	mouseDY float32
+	keybits uint16
+}
+
+// InputSource supplies one recordedFrame per game loop iteration, either
+// sampled live from raylib (RaylibInput) or replayed from a log written by
+// RecordingInput (ReplayInput).
+type InputSource interface {
+	// NextFrame returns the next frame of input. ok is false once a replay
+	// log is exhausted; RaylibInput always returns true.
+	NextFrame() (recordedFrame, bool)
+}
+
+// RaylibInput samples the current frame's input directly from raylib.
+type RaylibInput struct{}
+
+func (RaylibInput) NextFrame() (recordedFrame, bool) {
+	d := rl.GetMouseDelta()
+	var bits uint16
+	if rl.IsKeyDown(rl.KeyW) {
+		bits |= keyW
+	}
+	if rl.IsKeyDown(rl.KeyS) {
+		bits |= keyS
+	}
+	if rl.IsKeyDown(rl.KeyA) {
+		bits |= keyA
+	}
+	if rl.IsKeyDown(rl.KeyD) {
+		bits |= keyD
+	}
+	if rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift) {
+		bits |= keyShift
+	}
+	if rl.IsKeyPressed(rl.KeySpace) {
+		bits |= keySpace
+	}
+	if rl.IsKeyPressed(rl.KeyTab) {
+		bits |= keyTab
+	}
+	if rl.IsKeyPressed(rl.KeyP) {
+		bits |= keyP
+	}
+	if rl.IsMouseButtonPressed(rl.MouseButtonLeft) {
+		bits |= mouseLeft
+	}
+	if rl.IsMouseButtonPressed(rl.MouseButtonRight) {
+		bits |= mouseRight
+	}
+	return recordedFrame{dt: rl.GetFrameTime(), mouseDX: d.X, mouseDY: d.Y, keybits: bits}, true
+}
+
+// RecordingInput wraps another InputSource, appending every frame it
+// returns to a binary log before passing it through unchanged.
+type RecordingInput struct {
+	src InputSource
+	f   *os.File
+}
+
+// NewRecordingInput creates path and starts logging src's frames to it.
+func NewRecordingInput(src InputSource, path string) (*RecordingInput, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &RecordingInput{src: src, f: f}, nil
+}
+
+func (r *RecordingInput) NextFrame() (recordedFrame, bool) {
+	frame, ok := r.src.NextFrame()
+	if ok {
+		binary.Write(r.f, binary.LittleEndian, frame)
+	}
+	return frame, ok
+}
+
+func (r *RecordingInput) Close() error {
+	return r.f.Close()
+}
+
+// replayDt is the fixed timestep substituted for every replayed frame's
+// recorded dt, so gravity/jump integration is bit-for-bit reproducible
+// regardless of the machine's actual frame rate when the log was made or
+// is being replayed.
+const replayDt = 1.0 / 60.0
+
+// ReplayInput reads back a log written by RecordingInput.
+type ReplayInput struct {
+	frames []recordedFrame
+	idx    int
+}
+
+// NewReplayInput loads every frame from path up front.
+func NewReplayInput(path string) (*ReplayInput, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var frames []recordedFrame
+	for {
+		// binary.Read can only fill exported fields, so each record is
+		// decoded into this mirror of recordedFrame's layout first.
+		var rec struct {
+			Dt, MouseDX, MouseDY float32
+			Keybits              uint16
+		}
+		if err := binary.Read(f, binary.LittleEndian, &rec); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		frames = append(frames, recordedFrame{dt: replayDt, mouseDX: rec.MouseDX, mouseDY: rec.MouseDY, keybits: rec.Keybits})
+	}
+	return &ReplayInput{frames: frames}, nil
+}
+
+func (r *ReplayInput) NextFrame() (recordedFrame, bool) {
+	if r.idx >= len(r.frames) {
+		return recordedFrame{}, false
+	}
+	frame := r.frames[r.idx]
+	r.idx++
+	return frame, true
+}
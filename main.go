@@ -1,21 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
+	"os"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-// Fast deterministic value "noise" in [0,1) — placeholder for real Perlin/Simplex
-func noise2D(x, y int) float32 {
-	n := uint32(x*73856093 ^ y*19349663 ^ 0x9e3779b9)
-	n ^= n << 13
-	n ^= n >> 17
-	n ^= n << 5
-	return float32(n%10000) / 10000.0
-}
-
 func clampInt(v, lo, hi int) int {
 	if v < lo {
 		return lo
@@ -26,41 +19,62 @@ func clampInt(v, lo, hi int) int {
 	return v
 }
 
-func shadeGreen(y int, maxY int) rl.Color {
-	if maxY < 1 {
-		maxY = 1
+func main() {
+	renderOut := flag.String("renderout", "", "write a software-rendered PNG screenshot here and exit, instead of opening a window")
+	renderIn := flag.String("renderinput", "", "recorded look script (one \"dt dx dy\" line per frame) to replay when -renderout is set (see headless.go); movement is not replayed")
+	seed := flag.Uint64("seed", 1, "world generation seed")
+	recordPath := flag.String("record", "", "log every frame's input to this file for later -replay")
+	replayPath := flag.String("replay", "", "replay input previously captured with -record, using a fixed timestep")
+	softRender := flag.Bool("softrender", false, "draw the live window with the CPU-only render package instead of raylib's GPU path, uploading the framebuffer as a texture each frame")
+	flag.Parse()
+
+	if *renderOut != "" {
+		runHeadless(*renderOut, *renderIn, *seed)
+		return
+	}
+
+	var input InputSource = RaylibInput{}
+	var recorder *RecordingInput
+	switch {
+	case *replayPath != "":
+		replay, err := NewReplayInput(*replayPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "replay: %v\n", err)
+			os.Exit(1)
+		}
+		input = replay
+	case *recordPath != "":
+		rec, err := NewRecordingInput(RaylibInput{}, *recordPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "record: %v\n", err)
+			os.Exit(1)
+		}
+		recorder = rec
+		input = rec
 	}
-	t := float32(y) / float32(maxY)
-	r := uint8(20 + 50*t)
-	g := uint8(100 + 100*t)
-	b := uint8(20 + 40*t)
-	return rl.NewColor(r, g, b, 255)
-}
 
-func main() {
 	const worldSize = 64
-	const blockSize float32 = 1.0
-	const eyeHeight float32 = 1.7
 
-	// LOD radii (in blocks). Tweak these for FPS vs. quality.
-	const nearR = 18
-	const midR = 36
-	const farR = 48 // anything beyond this is skipped
+	// farR bounds how many chunks around the player are even considered for
+	// drawing; the frustum test below then thins that set further.
+	const farR = 48
 
-	// View-cone threshold: dot(forward, toCell) must be >= minDot to render
-	const minDot float32 = -0.15 // allow a fairly wide cone; raise toward 0.2 to cull harder
+	// Frustum near/far clip distances used for culling.
+	const frustumNear float32 = 0.1
+	const frustumFar float32 = float32(farR) + 1
 
 	rl.InitWindow(1280, 720, "Go Minecraft Prototype (raylib-go) - Culling + LOD")
 	defer rl.CloseWindow()
 	rl.SetTargetFPS(60)
 
-	// Camera yaw/pitch (radians)
-	var yaw float32 = math.Pi
-	var pitch float32 = -0.15
-	const mouseSens float32 = 0.003
+	st := playerState{
+		pos:   rl.NewVector3(32, 28, 80),
+		yaw:   math.Pi,
+		pitch: -0.15,
+	}
 
 	camera := rl.Camera3D{
-		Position:   rl.NewVector3(32, 28, 80),
+		Position:   st.pos,
 		Target:     rl.NewVector3(32, 28, 79),
 		Up:         rl.NewVector3(0, 1, 0),
 		Fovy:       60.0,
@@ -69,33 +83,33 @@ func main() {
 
 	cursorLocked := true
 	rl.DisableCursor()
-
-	// Generate height map
-	heightMap := make([]int, worldSize*worldSize)
-	maxH := 0
-	for z := 0; z < worldSize; z++ {
-		for x := 0; x < worldSize; x++ {
-			n := (noise2D(x, z) + noise2D(x+1, z) + noise2D(x, z+1) + noise2D(x+1, z+1)) * 0.25
-			h := int(10 + n*22) // ~10..32
-			heightMap[z*worldSize+x] = h
-			if h > maxH {
-				maxH = h
-			}
-		}
+	if recorder != nil {
+		defer recorder.Close()
 	}
 
-	// Player physics
-	var velY float32 = 0
-	const gravity float32 = -18.0
-	const jumpSpeed float32 = 6.5
-	moveSpeed := float32(6.0)
-	sprintMult := float32(1.8)
+	// Generate terrain
+	w := generateWorld(worldSize, *seed)
+
+	meshCache := newChunkMeshCache()
+	chunksX := (worldSize + chunkSize - 1) / chunkSize
+	chunksZ := chunksX
+	chunksY := worldHeight / chunkSize
+
+	var soft *softRenderer
+	if *softRender {
+		soft = newSoftRenderer(320, 180)
+	}
 
 	for !rl.WindowShouldClose() {
-		dt := rl.GetFrameTime()
+		frame, ok := input.NextFrame()
+		if !ok {
+			fmt.Printf("replay finished: pos=(%.2f,%.2f,%.2f) yaw=%.2f pitch=%.2f\n",
+				st.pos.X, st.pos.Y, st.pos.Z, st.yaw, st.pitch)
+			break
+		}
 
 		// Input toggles
-		if rl.IsKeyPressed(rl.KeyTab) {
+		if frame.keybits&keyTab != 0 {
 			cursorLocked = !cursorLocked
 			if cursorLocked {
 				rl.DisableCursor()
@@ -103,142 +117,105 @@ func main() {
 				rl.EnableCursor()
 			}
 		}
-		if rl.IsKeyPressed(rl.KeyP) {
+		if frame.keybits&keyP != 0 {
 			fmt.Printf("pos=(%.2f,%.2f,%.2f) yaw=%.2f pitch=%.2f\n",
-				camera.Position.X, camera.Position.Y, camera.Position.Z, yaw, pitch)
+				st.pos.X, st.pos.Y, st.pos.Z, st.yaw, st.pitch)
 		}
 
-		// Mouse look
-		if cursorLocked {
-			d := rl.GetMouseDelta()
-			yaw -= d.X * mouseSens
-			pitch -= d.Y * mouseSens
-			limit := float32(math.Pi / 3)
-			if pitch > limit {
-				pitch = limit
-			}
-			if pitch < -limit {
-				pitch = -limit
+		forward, right := stepPlayer(&st, w, worldSize, frame, cursorLocked)
+		camera.Position = st.pos
+		camera.Target = rl.Vector3Add(camera.Position, forward)
+		px := clampInt(int(math.Floor(float64(st.pos.X+0.5))), 0, worldSize-1)
+		pz := clampInt(int(math.Floor(float64(st.pos.Z+0.5))), 0, worldSize-1)
+
+		// Block interaction: raycast from the eye along the view direction
+		// and break/place whatever block it hits first.
+		const reach = 8.0
+		targeted, targetOk := raycastVoxel(w, camera.Position, forward, reach)
+		if targetOk {
+			if frame.keybits&mouseLeft != 0 {
+				w.Set(targeted.x, targeted.y, targeted.z, BlockAir)
+				meshCache.invalidateBlock(targeted.x, targeted.y, targeted.z)
+				fmt.Printf("broke block (%d,%d,%d) face=(%d,%d,%d)\n",
+					targeted.x, targeted.y, targeted.z, targeted.faceX, targeted.faceY, targeted.faceZ)
+			} else if frame.keybits&mouseRight != 0 {
+				px, py, pz := targeted.x+targeted.faceX, targeted.y+targeted.faceY, targeted.z+targeted.faceZ
+				w.Set(px, py, pz, w.At(targeted.x, targeted.y, targeted.z))
+				meshCache.invalidateBlock(px, py, pz)
+				fmt.Printf("placed block (%d,%d,%d)\n", px, py, pz)
 			}
 		}
 
-		// Forward/right from yaw/pitch
-		cp := float32(math.Cos(float64(pitch)))
-		sp := float32(math.Sin(float64(pitch)))
-		sy := float32(math.Sin(float64(yaw)))
-		cy := float32(math.Cos(float64(yaw)))
-
-		forward := rl.NewVector3(cp*sy, sp, -cp*cy)
-		forward = rl.Vector3Normalize(forward)
-		right := rl.Vector3Normalize(rl.Vector3CrossProduct(forward, camera.Up))
-
-		// Movement
-		speed := moveSpeed
-		if rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift) {
-			speed *= sprintMult
-		}
-		move := rl.NewVector3(0, 0, 0)
-		if rl.IsKeyDown(rl.KeyW) {
-			move = rl.Vector3Add(move, forward)
-		}
-		if rl.IsKeyDown(rl.KeyS) {
-			move = rl.Vector3Subtract(move, forward)
-		}
-		if rl.IsKeyDown(rl.KeyA) {
-			move = rl.Vector3Subtract(move, right)
-		}
-		if rl.IsKeyDown(rl.KeyD) {
-			move = rl.Vector3Add(move, right)
-		}
-		if move.X != 0 || move.Y != 0 || move.Z != 0 {
-			move = rl.Vector3Scale(rl.Vector3Normalize(move), speed*dt)
-			camera.Position = rl.Vector3Add(camera.Position, move)
-		}
-
-		// Gravity + ground from heightmap under player
-		velY += gravity * dt
-		camera.Position.Y += velY * dt
-		px := clampInt(int(math.Floor(float64(camera.Position.X+0.5))), 0, worldSize-1)
-		pz := clampInt(int(math.Floor(float64(camera.Position.Z+0.5))), 0, worldSize-1)
-		ground := float32(heightMap[pz*worldSize+px])
-		minY := ground + eyeHeight
-		onGround := false
-		if camera.Position.Y <= minY {
-			camera.Position.Y = minY
-			velY = 0
-			onGround = true
-		}
-		if onGround && rl.IsKeyPressed(rl.KeySpace) {
-			velY = jumpSpeed
-		}
-
-		camera.Target = rl.Vector3Add(camera.Position, forward)
-
 		// -------- drawing --------
 		rl.BeginDrawing()
 		rl.ClearBackground(rl.SkyBlue)
 
-		rl.BeginMode3D(camera)
-		rl.DrawGrid(64, 1.0)
-
-		// Culling bounds in XZ around player
-		minX := clampInt(px-farR, 0, worldSize-1)
-		maxX := clampInt(px+farR, 0, worldSize-1)
-		minZ := clampInt(pz-farR, 0, worldSize-1)
-		maxZ := clampInt(pz+farR, 0, worldSize-1)
-
-		near2 := nearR * nearR
-		mid2 := midR * midR
-		far2 := farR * farR
-
-		var cubesDrawn int
-
-		for z := minZ; z <= maxZ; z++ {
-			for x := minX; x <= maxX; x++ {
-				// Distance cull
-				dx := x - px
-				dz := z - pz
-				dist2 := dx*dx + dz*dz
-				if dist2 > far2 {
-					continue
-				}
-
-				// View-cone cull (dot product)
-				to := rl.NewVector3(float32(x)+0.5-camera.Position.X, 0, float32(z)+0.5-camera.Position.Z)
-				to = rl.Vector3Normalize(to)
-				dot := forward.X*to.X + forward.Z*to.Z // ignore Y for cone test
-				if dot < minDot {
-					continue
-				}
-
-				h := heightMap[z*worldSize+x]
-				if h <= 0 {
-					continue
-				}
+		camUp := rl.Vector3Normalize(rl.Vector3CrossProduct(right, forward))
+
+		var chunksDrawn int
+
+		if soft != nil {
+			// -softrender: skip raylib's GPU model path entirely and blit
+			// the CPU rasterizer's framebuffer instead (see softrender.go).
+			soft.render(w, toRenderVec3(camera.Position), toRenderVec3(forward), toRenderVec3(camUp), toRenderVec3(right), targeted, targetOk)
+			soft.draw(int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight()))
+		} else {
+			rl.BeginMode3D(camera)
+			rl.DrawGrid(64, 1.0)
+
+			aspect := float32(rl.GetScreenWidth()) / float32(rl.GetScreenHeight())
+			camFrustum := buildFrustum(camera.Position, forward, camUp, right, camera.Fovy, aspect, frustumNear, frustumFar)
+
+			// Greedy-meshed chunks replace the old per-cube draw loop: one
+			// cached rl.Model per 16x16x16 chunk instead of one rl.DrawCube per
+			// visible block. Chunks outside farR or outside the frustum are
+			// skipped before even touching the mesh cache.
+			pcx := clampInt(px/chunkSize, 0, chunksX-1)
+			pcz := clampInt(pz/chunkSize, 0, chunksZ-1)
+			chunkReach := farR/chunkSize + 1
+
+			for cz := clampInt(pcz-chunkReach, 0, chunksZ-1); cz <= clampInt(pcz+chunkReach, 0, chunksZ-1); cz++ {
+				for cx := clampInt(pcx-chunkReach, 0, chunksX-1); cx <= clampInt(pcx+chunkReach, 0, chunksX-1); cx++ {
+					dx := cx*chunkSize + chunkSize/2 - px
+					dz := cz*chunkSize + chunkSize/2 - pz
+					if dx*dx+dz*dz > (farR+chunkSize)*(farR+chunkSize) {
+						continue
+					}
 
-				if dist2 <= near2 {
-					// NEAR: draw full column (blocky up close)
-					for y := 0; y < h; y++ {
-						pos := rl.NewVector3(float32(x), float32(y), float32(z))
-						rl.DrawCube(pos, blockSize, blockSize, blockSize, shadeGreen(y, maxH))
-						cubesDrawn++
+					for cy := 0; cy < chunksY; cy++ {
+						box := aabb{
+							min: rl.NewVector3(float32(cx*chunkSize), float32(cy*chunkSize), float32(cz*chunkSize)),
+							max: rl.NewVector3(float32((cx+1)*chunkSize), float32((cy+1)*chunkSize), float32((cz+1)*chunkSize)),
+						}
+						if !camFrustum.intersectsAABB(box) {
+							continue
+						}
+
+						model, ok := meshCache.modelFor(cx, cy, cz, w, w.maxH)
+						if !ok {
+							continue
+						}
+						rl.DrawModel(model, rl.NewVector3(0, 0, 0), 1.0, rl.White)
+						chunksDrawn++
 					}
-				} else if dist2 <= mid2 {
-					// MID: draw only top block
-					y := h - 1
-					pos := rl.NewVector3(float32(x), float32(y), float32(z))
-					rl.DrawCube(pos, blockSize, blockSize, blockSize, shadeGreen(y, maxH))
-					cubesDrawn++
 				}
-				// FAR: skipped
 			}
-		}
 
-		rl.EndMode3D()
+			if targetOk {
+				center := rl.NewVector3(float32(targeted.x)+0.5, float32(targeted.y)+0.5, float32(targeted.z)+0.5)
+				rl.DrawCubeWires(center, 1.02, 1.02, 1.02, rl.Black)
+			}
+
+			rl.EndMode3D()
+		}
 
 		rl.DrawFPS(10, 10)
-		rl.DrawText(fmt.Sprintf("cubes drawn: %d", cubesDrawn), 10, 30, 16, rl.Black)
-		rl.DrawText("TAB: mouse lock | Shift: sprint | Space: jump", 10, 50, 16, rl.Black)
+		if soft != nil {
+			rl.DrawText("softrender: CPU rasterizer blitted as a texture", 10, 30, 16, rl.Black)
+		} else {
+			rl.DrawText(fmt.Sprintf("chunks drawn: %d", chunksDrawn), 10, 30, 16, rl.Black)
+		}
+		rl.DrawText("TAB: mouse lock | Shift: sprint | Space: jump | LMB: break | RMB: place", 10, 50, 16, rl.Black)
 
 		rl.EndDrawing()
 	}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// eyeHeight is how far the camera sits above the ground block under it.
+const eyeHeight float32 = 1.7
+
+const (
+	mouseSens  float32 = 0.003
+	gravity    float32 = -18.0
+	jumpSpeed  float32 = 6.5
+	moveSpeed  float32 = 6.0
+	sprintMult float32 = 1.8
+)
+
+var pitchLimit = float32(math.Pi / 3)
+
+// playerState is the camera/physics state that evolves one frame at a
+// time under look, movement and gravity. It's factored out of main's loop
+// so a recorded input log can be replayed against it deterministically in
+// tests, without a live raylib window - see player_test.go.
+type playerState struct {
+	pos        rl.Vector3
+	velY       float32
+	yaw, pitch float32
+}
+
+// stepPlayer advances st by one frame of input: mouse look (only while
+// cursorLocked), WASD movement relative to yaw/pitch, gravity, and ground
+// clamping against w.GroundHeight over a worldSize x worldSize column grid.
+// It returns the frame's forward and right vectors, which callers need for
+// the camera target, frustum and block raycast.
+func stepPlayer(st *playerState, w *world, worldSize int, frame recordedFrame, cursorLocked bool) (forward, right rl.Vector3) {
+	if cursorLocked {
+		st.yaw -= frame.mouseDX * mouseSens
+		st.pitch -= frame.mouseDY * mouseSens
+		if st.pitch > pitchLimit {
+			st.pitch = pitchLimit
+		}
+		if st.pitch < -pitchLimit {
+			st.pitch = -pitchLimit
+		}
+	}
+
+	cp := float32(math.Cos(float64(st.pitch)))
+	sp := float32(math.Sin(float64(st.pitch)))
+	sy := float32(math.Sin(float64(st.yaw)))
+	cy := float32(math.Cos(float64(st.yaw)))
+
+	forward = rl.Vector3Normalize(rl.NewVector3(cp*sy, sp, -cp*cy))
+	right = rl.Vector3Normalize(rl.Vector3CrossProduct(forward, rl.NewVector3(0, 1, 0)))
+
+	speed := moveSpeed
+	if frame.keybits&keyShift != 0 {
+		speed *= sprintMult
+	}
+	move := rl.NewVector3(0, 0, 0)
+	if frame.keybits&keyW != 0 {
+		move = rl.Vector3Add(move, forward)
+	}
+	if frame.keybits&keyS != 0 {
+		move = rl.Vector3Subtract(move, forward)
+	}
+	if frame.keybits&keyA != 0 {
+		move = rl.Vector3Subtract(move, right)
+	}
+	if frame.keybits&keyD != 0 {
+		move = rl.Vector3Add(move, right)
+	}
+	if move.X != 0 || move.Y != 0 || move.Z != 0 {
+		move = rl.Vector3Scale(rl.Vector3Normalize(move), speed*frame.dt)
+		st.pos = rl.Vector3Add(st.pos, move)
+	}
+
+	st.velY += gravity * frame.dt
+	st.pos.Y += st.velY * frame.dt
+	px := clampInt(int(math.Floor(float64(st.pos.X+0.5))), 0, worldSize-1)
+	pz := clampInt(int(math.Floor(float64(st.pos.Z+0.5))), 0, worldSize-1)
+	ground := float32(w.GroundHeight(px, pz))
+	minY := ground + eyeHeight
+	onGround := st.pos.Y <= minY
+	if onGround {
+		st.pos.Y = minY
+		st.velY = 0
+	}
+	if onGround && frame.keybits&keySpace != 0 {
+		st.velY = jumpSpeed
+	}
+
+	return forward, right
+}
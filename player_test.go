@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// flatWorld builds a world of the given size with every column filled
+// solid up to height h. stepPlayer's ground clamping against a flat world
+// is exact arithmetic; against the noise-generated terrain in world.go it
+// isn't, since the player could cross into a column with different
+// height mid-trajectory.
+func flatWorld(size, h int) *world {
+	w := &world{size: size, maxH: h, blocks: make([]BlockID, size*size*worldHeight)}
+	for z := 0; z < size; z++ {
+		for x := 0; x < size; x++ {
+			for y := 0; y < h; y++ {
+				w.blocks[w.index(x, y, z)] = BlockPlains
+			}
+		}
+	}
+	return w
+}
+
+// writeReplayLog writes n copies of frame to path in the binary format
+// RecordingInput/ReplayInput round-trip through (see input.go).
+func writeReplayLog(path string, n int, frame recordedFrame) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for i := 0; i < n; i++ {
+		if err := binary.Write(f, binary.LittleEndian, frame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestStepPlayerReplayTrajectory records a fixed-dt "hold W" input log,
+// replays it back through ReplayInput + stepPlayer, and checks the final
+// position lands where the physics predicts: straight down -Z at
+// moveSpeed (yaw/pitch both 0, so forward is exactly (0,0,-1) with no
+// trig rounding), settled on the flat ground plane. This is the
+// deterministic regression check the fixed-dt ReplayInput path exists for.
+func TestStepPlayerReplayTrajectory(t *testing.T) {
+	const size = 16
+	const groundHeight = 4
+	const frames = 30
+	w := flatWorld(size, groundHeight)
+
+	path := filepath.Join(t.TempDir(), "replay.bin")
+	if err := writeReplayLog(path, frames, recordedFrame{keybits: keyW}); err != nil {
+		t.Fatalf("writeReplayLog: %v", err)
+	}
+
+	replay, err := NewReplayInput(path)
+	if err != nil {
+		t.Fatalf("NewReplayInput: %v", err)
+	}
+
+	st := playerState{pos: rl.NewVector3(8, float32(groundHeight)+eyeHeight, 8)}
+	var n int
+	for {
+		frame, ok := replay.NextFrame()
+		if !ok {
+			break
+		}
+		stepPlayer(&st, w, size, frame, true)
+		n++
+	}
+	if n != frames {
+		t.Fatalf("replayed %d frames, want %d", n, frames)
+	}
+
+	wantZ := float32(8) - float32(frames)*moveSpeed*replayDt
+	if math.Abs(float64(st.pos.Z-wantZ)) > 1e-3 {
+		t.Errorf("final Z = %v, want %v", st.pos.Z, wantZ)
+	}
+	if st.pos.X != 8 {
+		t.Errorf("final X = %v, want unchanged 8 (forward has no X component at yaw=0)", st.pos.X)
+	}
+	wantY := float32(groundHeight) + eyeHeight
+	if st.pos.Y != wantY {
+		t.Errorf("final Y = %v, want resting on ground at %v", st.pos.Y, wantY)
+	}
+}
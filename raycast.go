@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// rayHit is the result of a successful voxel raycast: the block that was
+// hit plus the face of it the ray entered through, so placement knows
+// which adjacent cell to fill.
+type rayHit struct {
+	x, y, z             int
+	faceX, faceY, faceZ int // outward normal of the hit face; exactly one component is +-1, or all 0 if origin starts inside a solid block
+}
+
+// raycastVoxel walks from origin along dir using Amanatides & Woo's voxel
+// DDA, stopping at the first solid block within maxDist blocks. ok is
+// false if nothing solid was hit in range.
+func raycastVoxel(w *world, origin, dir rl.Vector3, maxDist float32) (hit rayHit, ok bool) {
+	dir = rl.Vector3Normalize(dir)
+	if dir.X == 0 && dir.Y == 0 && dir.Z == 0 {
+		return rayHit{}, false
+	}
+
+	ix := int(math.Floor(float64(origin.X)))
+	iy := int(math.Floor(float64(origin.Y)))
+	iz := int(math.Floor(float64(origin.Z)))
+
+	stepX, tDeltaX, tMaxX := ddaAxis(origin.X, dir.X, ix)
+	stepY, tDeltaY, tMaxY := ddaAxis(origin.Y, dir.Y, iy)
+	stepZ, tDeltaZ, tMaxZ := ddaAxis(origin.Z, dir.Z, iz)
+
+	var faceX, faceY, faceZ int
+	var t float32
+
+	for t <= maxDist {
+		if w.At(ix, iy, iz) != BlockAir {
+			return rayHit{x: ix, y: iy, z: iz, faceX: faceX, faceY: faceY, faceZ: faceZ}, true
+		}
+
+		switch {
+		case tMaxX < tMaxY && tMaxX < tMaxZ:
+			ix += stepX
+			t = tMaxX
+			tMaxX += tDeltaX
+			faceX, faceY, faceZ = -stepX, 0, 0
+		case tMaxY < tMaxZ:
+			iy += stepY
+			t = tMaxY
+			tMaxY += tDeltaY
+			faceX, faceY, faceZ = 0, -stepY, 0
+		default:
+			iz += stepZ
+			t = tMaxZ
+			tMaxZ += tDeltaZ
+			faceX, faceY, faceZ = 0, 0, -stepZ
+		}
+	}
+	return rayHit{}, false
+}
+
+// ddaAxis computes one axis's initial DDA state: step is the voxel-index
+// step direction (+-1, or 0 if dir has no component on this axis), tDelta
+// is the parametric distance to cross one full voxel along the ray, and
+// tMax is the distance to the first voxel boundary crossed on this axis.
+func ddaAxis(origin, dir float32, voxel int) (step int, tDelta, tMax float32) {
+	switch {
+	case dir > 0:
+		step = 1
+		tDelta = 1 / dir
+		tMax = (float32(voxel+1) - origin) * tDelta
+	case dir < 0:
+		step = -1
+		tDelta = 1 / -dir
+		tMax = (origin - float32(voxel)) * tDelta
+	default:
+		step = 0
+		tDelta = float32(math.Inf(1))
+		tMax = float32(math.Inf(1))
+	}
+	return step, tDelta, tMax
+}
@@ -0,0 +1,85 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+)
+
+// Framebuffer is an offscreen color + depth render target. Depth compares
+// as "smaller is nearer", matching the view-space distance DrawTriangle is
+// given.
+type Framebuffer struct {
+	Width, Height int
+	Color         []Color
+	Depth         []float32
+}
+
+// NewFramebuffer allocates a width x height framebuffer with depth cleared
+// to +Inf (nothing drawn yet).
+func NewFramebuffer(width, height int) *Framebuffer {
+	fb := &Framebuffer{
+		Width:  width,
+		Height: height,
+		Color:  make([]Color, width*height),
+		Depth:  make([]float32, width*height),
+	}
+	fb.clearDepth()
+	return fb
+}
+
+func (fb *Framebuffer) clearDepth() {
+	inf := float32(math.Inf(1))
+	for i := range fb.Depth {
+		fb.Depth[i] = inf
+	}
+}
+
+// Clear resets every pixel to c and the depth buffer to +Inf.
+func (fb *Framebuffer) Clear(c Color) {
+	for i := range fb.Color {
+		fb.Color[i] = c
+	}
+	fb.clearDepth()
+}
+
+func (fb *Framebuffer) index(x, y int) int { return y*fb.Width + x }
+
+// TestAndSet depth-tests (x, y, z) against the buffer and, if it passes,
+// writes c and the new depth. Reports whether the pixel was written.
+func (fb *Framebuffer) TestAndSet(x, y int, z float32, c Color) bool {
+	if x < 0 || x >= fb.Width || y < 0 || y >= fb.Height {
+		return false
+	}
+	i := fb.index(x, y)
+	if z >= fb.Depth[i] {
+		return false
+	}
+	fb.Depth[i] = z
+	fb.Color[i] = c
+	return true
+}
+
+// ToImage copies the color buffer into a standard library image.RGBA.
+func (fb *Framebuffer) ToImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, fb.Width, fb.Height))
+	for y := 0; y < fb.Height; y++ {
+		for x := 0; x < fb.Width; x++ {
+			c := fb.Color[fb.index(x, y)]
+			img.SetRGBA(x, y, color.RGBA{R: c.R, G: c.G, B: c.B, A: c.A})
+		}
+	}
+	return img
+}
+
+// SavePNG encodes the current color buffer as a PNG at path.
+func (fb *Framebuffer) SavePNG(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, fb.ToImage())
+}
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Mesh is a flat triangle list loaded from an OBJ file.
+type Mesh struct {
+	Triangles [][3]Vec3
+}
+
+// LoadOBJ parses a minimal subset of Wavefront OBJ: "v x y z" vertex lines
+// and "f ..." face lines, where each face token is "v", "v/vt" or "v/vt/vn"
+// (only the vertex index is used). Faces with more than three vertices are
+// triangulated as a fan. Normals, materials, and negative/relative indices
+// are not supported.
+func LoadOBJ(path string) (*Mesh, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var verts []Vec3
+	mesh := &Mesh{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("render: bad vertex line %q", line)
+			}
+			x, err1 := strconv.ParseFloat(fields[1], 32)
+			y, err2 := strconv.ParseFloat(fields[2], 32)
+			z, err3 := strconv.ParseFloat(fields[3], 32)
+			if err1 != nil || err2 != nil || err3 != nil {
+				return nil, fmt.Errorf("render: bad vertex line %q", line)
+			}
+			verts = append(verts, Vec3{X: float32(x), Y: float32(y), Z: float32(z)})
+
+		case "f":
+			idx := make([]int, 0, len(fields)-1)
+			for _, tok := range fields[1:] {
+				vStr := strings.SplitN(tok, "/", 2)[0]
+				vi, err := strconv.Atoi(vStr)
+				if err != nil {
+					return nil, fmt.Errorf("render: bad face index %q", tok)
+				}
+				if vi < 1 || vi > len(verts) {
+					return nil, fmt.Errorf("render: face index %d out of range (have %d verts)", vi, len(verts))
+				}
+				idx = append(idx, vi-1)
+			}
+			for i := 1; i+1 < len(idx); i++ {
+				mesh.Triangles = append(mesh.Triangles, [3]Vec3{verts[idx[0]], verts[idx[i]], verts[idx[i+1]]})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mesh, nil
+}
@@ -0,0 +1,122 @@
+package render
+
+// Vertex is a rasterizer input: a screen-space position (X, Y in pixels,
+// Z increasing with distance from the camera for the depth test) plus a
+// flat per-vertex color.
+type Vertex struct {
+	Pos   Vec3
+	Color Color
+}
+
+// edgeFunction is twice the signed area of triangle (a, b, c); its sign
+// flips depending on which side of edge a->b the point c is on.
+func edgeFunction(a, b, c Vec3) float32 {
+	return (c.X-a.X)*(b.Y-a.Y) - (c.Y-a.Y)*(b.X-a.X)
+}
+
+// DrawTriangle rasterizes triangle v0-v1-v2 into fb using edge-function
+// (barycentric) interpolation for color and depth, with a per-pixel Z-buffer
+// test. Degenerate (zero-area) triangles are skipped.
+func (fb *Framebuffer) DrawTriangle(v0, v1, v2 Vertex) {
+	area := edgeFunction(v0.Pos, v1.Pos, v2.Pos)
+	if area == 0 {
+		return
+	}
+
+	minX := minInt(fb.Width-1, maxInt(0, floorInt(min3(v0.Pos.X, v1.Pos.X, v2.Pos.X))))
+	maxX := minInt(fb.Width-1, maxInt(0, ceilInt(max3(v0.Pos.X, v1.Pos.X, v2.Pos.X))))
+	minY := minInt(fb.Height-1, maxInt(0, floorInt(min3(v0.Pos.Y, v1.Pos.Y, v2.Pos.Y))))
+	maxY := minInt(fb.Height-1, maxInt(0, ceilInt(max3(v0.Pos.Y, v1.Pos.Y, v2.Pos.Y))))
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			p := Vec3{X: float32(x) + 0.5, Y: float32(y) + 0.5}
+
+			w0 := edgeFunction(v1.Pos, v2.Pos, p)
+			w1 := edgeFunction(v2.Pos, v0.Pos, p)
+			w2 := edgeFunction(v0.Pos, v1.Pos, p)
+
+			// Inside the triangle iff all three barycentric weights share
+			// the sign of the triangle's area (handles both winding orders).
+			if !((w0 >= 0 && w1 >= 0 && w2 >= 0) || (w0 <= 0 && w1 <= 0 && w2 <= 0)) {
+				continue
+			}
+
+			b0 := w0 / area
+			b1 := w1 / area
+			b2 := w2 / area
+
+			z := b0*v0.Pos.Z + b1*v1.Pos.Z + b2*v2.Pos.Z
+			c := Color{
+				R: lerpByte(v0.Color.R, v1.Color.R, v2.Color.R, b0, b1, b2),
+				G: lerpByte(v0.Color.G, v1.Color.G, v2.Color.G, b0, b1, b2),
+				B: lerpByte(v0.Color.B, v1.Color.B, v2.Color.B, b0, b1, b2),
+				A: lerpByte(v0.Color.A, v1.Color.A, v2.Color.A, b0, b1, b2),
+			}
+			fb.TestAndSet(x, y, z, c)
+		}
+	}
+}
+
+func lerpByte(a, b, c uint8, wa, wb, wc float32) uint8 {
+	v := wa*float32(a) + wb*float32(b) + wc*float32(c)
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
+
+func min3(a, b, c float32) float32 {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+func max3(a, b, c float32) float32 {
+	m := a
+	if b > m {
+		m = b
+	}
+	if c > m {
+		m = c
+	}
+	return m
+}
+
+func floorInt(v float32) int {
+	i := int(v)
+	if v < float32(i) {
+		i--
+	}
+	return i
+}
+
+func ceilInt(v float32) int {
+	i := int(v)
+	if v > float32(i) {
+		i++
+	}
+	return i
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
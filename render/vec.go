@@ -0,0 +1,35 @@
+// Package render is a small CPU-only software rasterizer: a Framebuffer with
+// a per-pixel Z-buffer, barycentric triangle fill, and a minimal OBJ loader.
+// It has no dependency on raylib (or any GPU API) so it can run in headless,
+// CPU-only environments such as CI.
+package render
+
+import "math"
+
+// Vec3 is this package's own 3D vector type, kept independent of raylib's.
+type Vec3 struct {
+	X, Y, Z float32
+}
+
+func (a Vec3) Add(b Vec3) Vec3 { return Vec3{a.X + b.X, a.Y + b.Y, a.Z + b.Z} }
+func (a Vec3) Sub(b Vec3) Vec3 { return Vec3{a.X - b.X, a.Y - b.Y, a.Z - b.Z} }
+func (a Vec3) Scale(s float32) Vec3 {
+	return Vec3{a.X * s, a.Y * s, a.Z * s}
+}
+func (a Vec3) Dot(b Vec3) float32 { return a.X*b.X + a.Y*b.Y + a.Z*b.Z }
+func (a Vec3) Length() float32    { return float32(math.Sqrt(float64(a.Dot(a)))) }
+
+// Normalize returns a unit-length copy of a, or the zero vector if a is too
+// short to normalize safely.
+func (a Vec3) Normalize() Vec3 {
+	l := a.Length()
+	if l < 1e-9 {
+		return Vec3{}
+	}
+	return a.Scale(1 / l)
+}
+
+// Color is a simple 8-bit-per-channel RGBA color.
+type Color struct {
+	R, G, B, A uint8
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/basperheim/minecraft-clone-raylib-go/render"
+)
+
+// softRenderer drives the realtime "-softrender" mode: each frame it
+// rasterizes the world with the CPU-only render package (the same
+// rasterizeWorld used by -renderout, plus drawHighlight for the targeted
+// block, which -renderout does not draw) into an offscreen
+// Framebuffer, then uploads that framebuffer into a single GPU Texture2D
+// via UpdateTexture and blits it full-screen. It exists so the CPU
+// rasterizer built for the -renderout screenshot path also has a live,
+// in-window path instead of only ever running once and exiting.
+type softRenderer struct {
+	fb        *render.Framebuffer
+	texture   rl.Texture2D
+	highlight *render.Mesh
+}
+
+// newSoftRenderer allocates a width x height framebuffer/texture pair and
+// loads the unit-cube mesh used to highlight the targeted block.
+func newSoftRenderer(width, height int) *softRenderer {
+	fb := render.NewFramebuffer(width, height)
+
+	img := rl.NewImage(unsafe.Pointer(&fb.Color[0]), int32(width), int32(height), 1, rl.UncompressedR8g8b8a8)
+	texture := rl.LoadTextureFromImage(img)
+
+	mesh, err := render.LoadOBJ("assets/cube.obj")
+	if err != nil {
+		// The highlight mesh is cosmetic; fall back to none rather than
+		// failing softrender mode entirely over a missing asset file.
+		mesh = &render.Mesh{}
+	}
+
+	return &softRenderer{fb: fb, texture: texture, highlight: mesh}
+}
+
+// render rasterizes w and, if targetOk, the targeted block's highlight
+// cube, then uploads the result into sr.texture.
+func (sr *softRenderer) render(w *world, camPos, forward, up, right render.Vec3, targeted rayHit, targetOk bool) {
+	sr.fb.Clear(render.Color{R: 135, G: 206, B: 235, A: 255})
+	rasterizeWorld(sr.fb, w, w.size, camPos, forward)
+	if targetOk {
+		drawHighlight(sr.fb, sr.highlight, targeted, camPos, forward, up, right)
+	}
+	rl.UpdateTexture(sr.texture, unsafe.Pointer(&sr.fb.Color[0]))
+}
+
+// draw blits sr.texture scaled to fill a destW x destH window.
+func (sr *softRenderer) draw(destW, destH int32) {
+	src := rl.NewRectangle(0, 0, float32(sr.fb.Width), float32(sr.fb.Height))
+	dst := rl.NewRectangle(0, 0, float32(destW), float32(destH))
+	rl.DrawTexturePro(sr.texture, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/basperheim/minecraft-clone-raylib-go/worldgen"
+)
+
+// BlockID names one voxel's contents: air, or a biome-tinted solid block.
+type BlockID uint8
+
+const (
+	BlockAir BlockID = iota
+	BlockPlains
+	BlockDesert
+	BlockForest
+	BlockMountain
+	BlockSnow
+)
+
+// blockForBiome maps a generated biome to the solid block placed for it.
+// worldgen.Biome and BlockID share ordering (BlockID = Biome + 1, since 0
+// is reserved for air).
+func blockForBiome(b worldgen.Biome) BlockID { return BlockID(b) + 1 }
+
+// biomeForBlock is blockForBiome's inverse, used when shading a solid block.
+func biomeForBlock(id BlockID) worldgen.Biome { return worldgen.Biome(id - 1) }
+
+// world is the full 3D voxel grid backing the game: worldSize x
+// worldHeight x worldSize blocks, addressable and mutable one block at a
+// time. This replaces the old flat per-column heightMap, which could only
+// ever represent a single solid run per column and couldn't support
+// removing a block mid-column or placing one in open air.
+type world struct {
+	size   int
+	maxH   int
+	blocks []BlockID
+}
+
+// generateWorld builds a world by sampling Perlin/Simplex fBm terrain plus
+// a biome per column (see generateColumn), then filling every block up to
+// each column's height with that biome's block.
+func generateWorld(worldSize int, seed uint64) *world {
+	w := &world{
+		size:   worldSize,
+		blocks: make([]BlockID, worldSize*worldSize*worldHeight),
+	}
+
+	for z := 0; z < worldSize; z++ {
+		for x := 0; x < worldSize; x++ {
+			h, biome := generateColumn(x, z, seed)
+			if h > w.maxH {
+				w.maxH = h
+			}
+			block := blockForBiome(biome)
+			for y := 0; y < h && y < worldHeight; y++ {
+				w.blocks[w.index(x, y, z)] = block
+			}
+		}
+	}
+	return w
+}
+
+// Noise frequencies are in cycles per block; continentFreq shapes the
+// broad fBm terrain, climateFreq is a separate, much lower frequency field
+// so biomes form large regions rather than flickering block to block.
+const (
+	continentFreq = 1.0 / 48.0
+	climateFreq   = 1.0 / 96.0
+	fbmOctaves    = 5
+	fbmPersist    = 0.5
+	fbmLacunarity = 2.0
+)
+
+// generateColumn replaces the old value-noise noise2D stub: it stacks 2D
+// Perlin fBm for continent shape, samples separate low-frequency Simplex
+// noise for temperature and humidity, and uses those to pick a biome (and
+// hence a height multiplier) for column (x, z).
+func generateColumn(x, z int, seed uint64) (height int, biome worldgen.Biome) {
+	fx, fz := float32(x)*continentFreq, float32(z)*continentFreq
+	heightNoise := worldgen.FBM(worldgen.Perlin2D, fx, fz, seed, fbmOctaves, fbmPersist, fbmLacunarity)
+
+	cx, cz := float32(x)*climateFreq, float32(z)*climateFreq
+	temperature := worldgen.FBM(worldgen.Simplex2D, cx, cz, seed+1, 3, 0.5, 2.0)
+	humidity := worldgen.FBM(worldgen.Simplex2D, cx, cz, seed+2, 3, 0.5, 2.0)
+
+	biome = worldgen.SelectBiome(heightNoise, temperature, humidity)
+
+	base := 10 + (heightNoise*0.5+0.5)*22 // ~10..32, same range noise2D used to give
+	height = int(base * biome.HeightMultiplier())
+	if height < 1 {
+		height = 1
+	}
+	if height > worldHeight {
+		height = worldHeight
+	}
+	return height, biome
+}
+
+func (w *world) index(x, y, z int) int {
+	return (y*w.size+z)*w.size + x
+}
+
+func (w *world) inBounds(x, y, z int) bool {
+	return x >= 0 && x < w.size && z >= 0 && z < w.size && y >= 0 && y < worldHeight
+}
+
+// At returns the block at (x, y, z), or BlockAir for out-of-bounds
+// coordinates (including above worldHeight).
+func (w *world) At(x, y, z int) BlockID {
+	if !w.inBounds(x, y, z) {
+		return BlockAir
+	}
+	return w.blocks[w.index(x, y, z)]
+}
+
+// Set writes the block at (x, y, z); out-of-bounds writes are ignored.
+func (w *world) Set(x, y, z int, id BlockID) {
+	if !w.inBounds(x, y, z) {
+		return
+	}
+	w.blocks[w.index(x, y, z)] = id
+}
+
+// GroundHeight returns the height of the top solid block under (x, z), for
+// player gravity. Unlike the old heightMap lookup, it scans down from the
+// top of the world rather than assuming a single solid run, since blocks
+// can now be broken out from under a column.
+func (w *world) GroundHeight(x, z int) int {
+	for y := worldHeight - 1; y >= 0; y-- {
+		if w.At(x, y, z) != BlockAir {
+			return y + 1
+		}
+	}
+	return 0
+}
+
+// biomeBaseColor is each biome's untinted surface color.
+func biomeBaseColor(b worldgen.Biome) rl.Color {
+	switch b {
+	case worldgen.BiomeDesert:
+		return rl.NewColor(194, 178, 128, 255)
+	case worldgen.BiomeForest:
+		return rl.NewColor(34, 90, 34, 255)
+	case worldgen.BiomeMountains:
+		return rl.NewColor(120, 120, 120, 255)
+	case worldgen.BiomeSnow:
+		return rl.NewColor(230, 235, 240, 255)
+	default: // plains
+		return rl.NewColor(70, 150, 60, 255)
+	}
+}
+
+// shadeColumn replaces shadeGreen: it shades height y of a maxY-tall world
+// darker near the ground and brighter near the top, scaling biome b's base
+// color from 60% at y=0 up to 100% at y=maxY, instead of a flat green.
+func shadeColumn(b worldgen.Biome, y, maxY int) rl.Color {
+	if maxY < 1 {
+		maxY = 1
+	}
+	t := float32(y) / float32(maxY)
+	scale := 0.6 + 0.4*t
+
+	base := biomeBaseColor(b)
+	return rl.NewColor(
+		scaleByte(base.R, scale),
+		scaleByte(base.G, scale),
+		scaleByte(base.B, scale),
+		255,
+	)
+}
+
+// blockColor shades a solid block the way shadeColumn shades its biome;
+// BlockAir has no color and should never reach here.
+func blockColor(id BlockID, y, maxY int) rl.Color {
+	return shadeColumn(biomeForBlock(id), y, maxY)
+}
+
+func scaleByte(v uint8, scale float32) uint8 {
+	scaled := float32(v) * scale
+	if scaled < 0 {
+		return 0
+	}
+	if scaled > 255 {
+		return 255
+	}
+	return uint8(scaled)
+}
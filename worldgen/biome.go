@@ -0,0 +1,71 @@
+package worldgen
+
+// Biome is one of the named terrain biomes selected by height, temperature
+// and humidity.
+type Biome int
+
+const (
+	BiomePlains Biome = iota
+	BiomeDesert
+	BiomeForest
+	BiomeMountains
+	BiomeSnow
+)
+
+func (b Biome) String() string {
+	switch b {
+	case BiomePlains:
+		return "plains"
+	case BiomeDesert:
+		return "desert"
+	case BiomeForest:
+		return "forest"
+	case BiomeMountains:
+		return "mountains"
+	case BiomeSnow:
+		return "snow"
+	default:
+		return "unknown"
+	}
+}
+
+// SelectBiome maps a continent-shape height sample and separate
+// temperature/humidity samples (each roughly in [-1, 1], higher = hotter /
+// wetter) to one of the five biomes. Height dominates at the extremes
+// (mountain peaks), then temperature and humidity pick among the rest.
+//
+// The mountain threshold is tuned below, not at, the 5-octave fBm's
+// theoretical peak: FBM(Perlin2D, ..., 5, 0.5, 2.0) peaks at roughly
+// 0.29-0.42 on a 64x64 world depending on seed, so BiomeMountains appears
+// only for seeds whose peak exceeds 0.35.
+func SelectBiome(height, temperature, humidity float32) Biome {
+	switch {
+	case height > 0.35:
+		return BiomeMountains
+	case temperature < -0.3:
+		return BiomeSnow
+	case temperature > 0.35 && humidity < -0.1:
+		return BiomeDesert
+	case humidity > 0.15:
+		return BiomeForest
+	default:
+		return BiomePlains
+	}
+}
+
+// HeightMultiplier scales the base terrain height per biome: mountains
+// stand tallest, deserts and plains are comparatively flat.
+func (b Biome) HeightMultiplier() float32 {
+	switch b {
+	case BiomeMountains:
+		return 1.6
+	case BiomeSnow:
+		return 1.2
+	case BiomeForest:
+		return 1.1
+	case BiomeDesert:
+		return 0.8
+	default: // plains
+		return 1.0
+	}
+}
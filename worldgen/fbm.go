@@ -0,0 +1,28 @@
+package worldgen
+
+// NoiseFunc is the shape shared by Perlin2D and Simplex2D, so FBM can stack
+// octaves of either.
+type NoiseFunc func(x, y float32, seed uint64) float32
+
+// FBM (fractal Brownian motion) sums octaves layers of noiseFn at
+// increasing frequency and decreasing amplitude: amplitude *= persistence,
+// frequency *= lacunarity each octave. The result is normalized back to
+// roughly [-1, 1] regardless of octave count.
+func FBM(noiseFn NoiseFunc, x, y float32, seed uint64, octaves int, persistence, lacunarity float32) float32 {
+	var total, amplitude, frequency float32 = 0, 1, 1
+	var maxAmplitude float32
+
+	for o := 0; o < octaves; o++ {
+		// Offsetting the seed per octave decorrelates layers that would
+		// otherwise sample the same noise field at related frequencies.
+		total += noiseFn(x*frequency, y*frequency, seed+uint64(o)) * amplitude
+		maxAmplitude += amplitude
+		amplitude *= persistence
+		frequency *= lacunarity
+	}
+
+	if maxAmplitude == 0 {
+		return 0
+	}
+	return total / maxAmplitude
+}
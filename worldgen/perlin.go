@@ -0,0 +1,105 @@
+// Package worldgen generates terrain via gradient noise (Perlin, Simplex)
+// stacked into fractal Brownian motion, plus a biome classifier over the
+// resulting height/temperature/humidity fields.
+package worldgen
+
+import "math"
+
+// perlinTables caches each seed's permutation table so repeated Perlin2D
+// calls with the same seed don't rebuild it every time.
+var perlinTables = map[uint64]*[512]int{}
+
+func permutationFor(seed uint64) *[512]int {
+	if t, ok := perlinTables[seed]; ok {
+		return t
+	}
+	t := newPermutation(seed)
+	perlinTables[seed] = t
+	return t
+}
+
+// newPermutation builds the standard 256-entry Perlin permutation table,
+// duplicated to 512 entries to avoid wrap-around checks, shuffled
+// deterministically from seed with a small xorshift64 PRNG.
+func newPermutation(seed uint64) *[512]int {
+	var p [256]int
+	for i := range p {
+		p[i] = i
+	}
+
+	state := seed
+	if state == 0 {
+		state = 0x9e3779b97f4a7c15
+	}
+	next := func() uint64 {
+		state ^= state << 13
+		state ^= state >> 7
+		state ^= state << 17
+		return state
+	}
+	for i := 255; i > 0; i-- {
+		j := int(next() % uint64(i+1))
+		p[i], p[j] = p[j], p[i]
+	}
+
+	var full [512]int
+	for i := range full {
+		full[i] = p[i%256]
+	}
+	return &full
+}
+
+// fade is Perlin's improved quintic easing curve: 6t^5 - 15t^4 + 10t^3.
+func fade(t float32) float32 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(t, a, b float32) float32 {
+	return a + t*(b-a)
+}
+
+// grad2D picks one of 8 gradient directions from the low 3 bits of hash and
+// dots it with (x, y).
+func grad2D(hash int, x, y float32) float32 {
+	switch hash & 7 {
+	case 0:
+		return x + y
+	case 1:
+		return x - y
+	case 2:
+		return -x + y
+	case 3:
+		return -x - y
+	case 4:
+		return x
+	case 5:
+		return -x
+	case 6:
+		return y
+	default:
+		return -y
+	}
+}
+
+// Perlin2D returns classic 2D gradient noise, roughly in [-1, 1], at (x, y)
+// using the permutation table for seed.
+func Perlin2D(x, y float32, seed uint64) float32 {
+	perm := permutationFor(seed)
+
+	xi := int(math.Floor(float64(x))) & 255
+	yi := int(math.Floor(float64(y))) & 255
+	xf := x - float32(math.Floor(float64(x)))
+	yf := y - float32(math.Floor(float64(y)))
+
+	u := fade(xf)
+	v := fade(yf)
+
+	aa := perm[perm[xi]+yi]
+	ab := perm[perm[xi]+yi+1]
+	ba := perm[perm[xi+1]+yi]
+	bb := perm[perm[xi+1]+yi+1]
+
+	x1 := lerp(u, grad2D(aa, xf, yf), grad2D(ba, xf-1, yf))
+	x2 := lerp(u, grad2D(ab, xf, yf-1), grad2D(bb, xf-1, yf-1))
+	return lerp(v, x1, x2)
+}
@@ -0,0 +1,66 @@
+package worldgen
+
+import "math"
+
+var simplexGrad2 = [8][2]float32{
+	{1, 1}, {-1, 1}, {1, -1}, {-1, -1},
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+}
+
+// Skewing/unskewing factors for 2D simplex noise: F2 = (sqrt(3)-1)/2,
+// G2 = (3-sqrt(3))/6.
+const (
+	simplexF2 = 0.3660254037844386
+	simplexG2 = 0.21132486540518713
+)
+
+// Simplex2D returns 2D simplex noise, roughly in [-1, 1], at (x, y) using
+// the permutation table for seed. Shares the permutation table (and hence
+// the cache) with Perlin2D.
+func Simplex2D(x, y float32, seed uint64) float32 {
+	perm := permutationFor(seed)
+
+	s := (x + y) * simplexF2
+	i := int(math.Floor(float64(x + s)))
+	j := int(math.Floor(float64(y + s)))
+
+	t := float32(i+j) * simplexG2
+	unskewX := float32(i) - t
+	unskewY := float32(j) - t
+	x0 := x - unskewX
+	y0 := y - unskewY
+
+	var i1, j1 int
+	if x0 > y0 {
+		i1, j1 = 1, 0
+	} else {
+		i1, j1 = 0, 1
+	}
+
+	x1 := x0 - float32(i1) + simplexG2
+	y1 := y0 - float32(j1) + simplexG2
+	x2 := x0 - 1 + 2*simplexG2
+	y2 := y0 - 1 + 2*simplexG2
+
+	ii := i & 255
+	jj := j & 255
+	gi0 := perm[ii+perm[jj]] & 7
+	gi1 := perm[ii+i1+perm[jj+j1]] & 7
+	gi2 := perm[ii+1+perm[jj+1]] & 7
+
+	n0 := simplexCorner(x0, y0, gi0)
+	n1 := simplexCorner(x1, y1, gi1)
+	n2 := simplexCorner(x2, y2, gi2)
+
+	return 70 * (n0 + n1 + n2)
+}
+
+func simplexCorner(x, y float32, gradIdx int) float32 {
+	t := float32(0.5) - x*x - y*y
+	if t < 0 {
+		return 0
+	}
+	t *= t
+	g := simplexGrad2[gradIdx]
+	return t * t * (g[0]*x + g[1]*y)
+}